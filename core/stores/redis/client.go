@@ -0,0 +1,156 @@
+package redis
+
+import (
+	"crypto/tls"
+	"errors"
+
+	red "github.com/redis/go-redis/v9"
+)
+
+var (
+	errHostEmpty       = errors.New("redis: host is empty")
+	errMasterNameEmpty = errors.New("redis: MasterName is required when Type is sentinel")
+	errNoHealthyShard  = errors.New("redis: no healthy ring shard available")
+	errRingClosed      = errors.New("redis: ring is already closed")
+)
+
+// A Redis wraps an underlying go-redis universal client (standalone,
+// sentinel or cluster), picked from the RedisConf it was built with.
+type Redis struct {
+	Addrs  []string
+	Type   string
+	client red.UniversalClient
+	// hook is the built-in tracing/metrics/slowlog hook installed on client;
+	// kept so Option funcs (WithStatementAttribute, WithMaxStatementLength)
+	// can tune it after construction.
+	hook *hook
+	// sharedKey is set when this Redis was obtained from Shared, so Close
+	// releases a reference instead of tearing down the client directly.
+	sharedKey string
+}
+
+// Close tears down the underlying go-redis client. If this Redis was
+// obtained from Shared, Close only releases this holder's reference and
+// the pool is torn down once the last holder releases it.
+func (r *Redis) Close() error {
+	if len(r.sharedKey) > 0 {
+		return releaseShared(r.sharedKey)
+	}
+
+	return r.client.Close()
+}
+
+// AddHook appends h to the client's hook chain, after whatever hooks are
+// already installed. The built-in tracing/metrics/slowlog hook is always
+// installed first, at construction time, so it sees every command.
+func (r *Redis) AddHook(h Hook) {
+	r.client.AddHook(h)
+}
+
+// newRedis builds a Redis from rc, dispatching to a standalone, sentinel or
+// cluster go-redis client depending on how rc.Host parses, and installs the
+// tracing/metrics hook uniformly on whichever kind is built.
+func newRedis(rc RedisConf, opts ...Option) (*Redis, error) {
+	if err := rc.Validate(); err != nil {
+		return nil, err
+	}
+
+	pa, err := parseHost(rc)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := buildClient(pa)
+	if err != nil {
+		return nil, err
+	}
+
+	h := newHook(rc, pa)
+	client.AddHook(h)
+
+	r := &Redis{
+		Addrs:  pa.addrs,
+		Type:   pa.kind,
+		client: client,
+		hook:   h,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r, nil
+}
+
+// TLSConfig builds the *tls.Config described by rc.Tls, for callers that
+// construct a go-redis client themselves instead of going through NewRedis.
+func (rc RedisConf) TLSConfig() (*tls.Config, error) {
+	return buildTLSConfig(rc.Tls)
+}
+
+// Options derives the red.Options matching rc, for callers that construct a
+// standalone go-redis client themselves instead of going through NewRedis.
+func (rc RedisConf) Options() (*red.Options, error) {
+	pa, err := parseHost(rc)
+	if err != nil {
+		return nil, err
+	}
+
+	addr := blankHost
+	if len(pa.addrs) > 0 {
+		addr = pa.addrs[0]
+	}
+
+	return &red.Options{
+		Addr:         addr,
+		DB:           pa.db,
+		Username:     pa.username,
+		Password:     pa.pass,
+		DialTimeout:  pa.dialTimeout,
+		ReadTimeout:  pa.readTimeout,
+		WriteTimeout: pa.writeTimeout,
+		TLSConfig:    pa.tls,
+	}, nil
+}
+
+// buildClient constructs the go-redis client matching pa.kind.
+func buildClient(pa parsedAddr) (red.UniversalClient, error) {
+	switch pa.kind {
+	case ClusterType:
+		return red.NewClusterClient(&red.ClusterOptions{
+			Addrs:        pa.addrs,
+			Username:     pa.username,
+			Password:     pa.pass,
+			DialTimeout:  pa.dialTimeout,
+			ReadTimeout:  pa.readTimeout,
+			WriteTimeout: pa.writeTimeout,
+			TLSConfig:    pa.tls,
+		}), nil
+	case SentinelType:
+		return red.NewFailoverClient(&red.FailoverOptions{
+			MasterName:    pa.masterName,
+			SentinelAddrs: pa.addrs,
+			DB:            pa.db,
+			Username:      pa.username,
+			Password:      pa.pass,
+			DialTimeout:   pa.dialTimeout,
+			ReadTimeout:   pa.readTimeout,
+			WriteTimeout:  pa.writeTimeout,
+			TLSConfig:     pa.tls,
+		}), nil
+	default:
+		addr := blankHost
+		if len(pa.addrs) > 0 {
+			addr = pa.addrs[0]
+		}
+		return red.NewClient(&red.Options{
+			Addr:         addr,
+			DB:           pa.db,
+			Username:     pa.username,
+			Password:     pa.pass,
+			DialTimeout:  pa.dialTimeout,
+			ReadTimeout:  pa.readTimeout,
+			WriteTimeout: pa.writeTimeout,
+			TLSConfig:    pa.tls,
+		}), nil
+	}
+}
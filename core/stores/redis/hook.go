@@ -20,26 +20,77 @@ import (
 )
 
 // spanName is the span name of the redis calls.
-const spanName = "redis"
+const (
+	spanName = "redis"
+	// defaultMaxStatementLength is the db.statement cap newHook uses when
+	// RedisConf.MaxStatementLength is 0; at 0 statements are not capped.
+	defaultMaxStatementLength = 0
+)
 
 var (
-	startTimeKey          = contextKey("startTime")
-	durationHook          = hook{}
-	redisCmdsAttributeKey = attribute.Key("redis.cmds")
+	startTimeKey = contextKey("startTime")
+
+	redisCmdsAttributeKey      = attribute.Key("redis.cmds")
+	dbSystemAttributeKey       = attribute.Key("db.system")
+	dbIndexAttributeKey        = attribute.Key("db.redis.database_index")
+	netPeerNameAttributeKey    = attribute.Key("net.peer.name")
+	netPeerPortAttributeKey    = attribute.Key("net.peer.port")
+	dbStatementAttributeKey    = attribute.Key("db.statement")
+	pipelineLengthAttributeKey = attribute.Key("db.redis.pipeline.length")
 )
 
 type (
 	contextKey string
-	hook       struct{}
+
+	// hook instruments a single go-redis client with tracing, metrics and
+	// slowlog reporting. It's built once per Redis (see newHook), carrying
+	// the bits of the resolved connection (db index, peer addr) that the
+	// OpenTelemetry semantic conventions want on every span.
+	hook struct {
+		dbIndex            int
+		addr               string
+		statementAttribute bool
+		maxStatementLength int
+	}
 )
 
-func (h hook) DialHook(next red.DialHook) red.DialHook {
+// A Hook mirrors red.Hook, letting callers register their own hooks (custom
+// log redaction, per-tenant metrics, circuit breaking around specific
+// commands, cache-aside instrumentation, ...) alongside the built-in one,
+// see Redis.AddHook and WithHooks. User hooks run after the built-in hook,
+// seeing the same context, including startTimeKey and the started span.
+type Hook = red.Hook
+
+// newHook builds the built-in hook for a client resolved from pa, honoring
+// rc's declarative statement-capture settings. Option funcs (see
+// WithStatementAttribute, WithMaxStatementLength) can still override these
+// per call site after construction.
+func newHook(rc RedisConf, pa parsedAddr) *hook {
+	addr := blankHost
+	if len(pa.addrs) > 0 {
+		addr = pa.addrs[0]
+	}
+
+	maxLen := rc.MaxStatementLength
+	if maxLen == 0 {
+		maxLen = defaultMaxStatementLength
+	}
+
+	return &hook{
+		dbIndex:            pa.db,
+		addr:               addr,
+		statementAttribute: !rc.DisableStatementAttribute,
+		maxStatementLength: maxLen,
+	}
+}
+
+func (h *hook) DialHook(next red.DialHook) red.DialHook {
 	return func(ctx context.Context, network, addr string) (net.Conn, error) {
 		return next(ctx, network, addr)
 	}
 }
 
-func (h hook) ProcessHook(next red.ProcessHook) red.ProcessHook {
+func (h *hook) ProcessHook(next red.ProcessHook) red.ProcessHook {
 	return func(ctx context.Context, cmd red.Cmder) error {
 		ctx = h.BeforeProcess(context.WithValue(ctx, startTimeKey, timex.Now()), cmd)
 
@@ -53,11 +104,11 @@ func (h hook) ProcessHook(next red.ProcessHook) red.ProcessHook {
 	}
 }
 
-func (h hook) BeforeProcess(ctx context.Context, cmd red.Cmder) context.Context {
+func (h *hook) BeforeProcess(ctx context.Context, cmd red.Cmder) context.Context {
 	return h.startSpan(context.WithValue(ctx, startTimeKey, timex.Now()), cmd)
 }
 
-func (h hook) AfterProcess(ctx context.Context, cmd red.Cmder) {
+func (h *hook) AfterProcess(ctx context.Context, cmd red.Cmder) {
 	err := cmd.Err()
 	h.endSpan(ctx, err)
 
@@ -83,7 +134,7 @@ func (h hook) AfterProcess(ctx context.Context, cmd red.Cmder) {
 	}
 }
 
-func (h hook) ProcessPipelineHook(next red.ProcessPipelineHook) red.ProcessPipelineHook {
+func (h *hook) ProcessPipelineHook(next red.ProcessPipelineHook) red.ProcessPipelineHook {
 	return func(ctx context.Context, cmds []red.Cmder) error {
 		ctx = h.BeforeProcessPipeline(ctx, cmds)
 
@@ -97,7 +148,7 @@ func (h hook) ProcessPipelineHook(next red.ProcessPipelineHook) red.ProcessPipel
 	}
 }
 
-func (h hook) BeforeProcessPipeline(ctx context.Context, cmds []red.Cmder) context.Context {
+func (h *hook) BeforeProcessPipeline(ctx context.Context, cmds []red.Cmder) context.Context {
 	if len(cmds) == 0 {
 		return ctx
 	}
@@ -105,14 +156,20 @@ func (h hook) BeforeProcessPipeline(ctx context.Context, cmds []red.Cmder) conte
 	return h.startSpan(context.WithValue(ctx, startTimeKey, timex.Now()), cmds...)
 }
 
-func (h hook) AfterProcessPipeline(ctx context.Context, cmds []red.Cmder) {
+func (h *hook) AfterProcessPipeline(ctx context.Context, cmds []red.Cmder) {
 	if len(cmds) == 0 {
 		return
 	}
 
+	span := oteltrace.SpanFromContext(ctx)
 	batchError := errorx.BatchError{}
 	for _, cmd := range cmds {
 		err := cmd.Err()
+		if h.statementAttribute {
+			span.AddEvent(cmd.Name(), oteltrace.WithAttributes(
+				dbStatementAttributeKey.String(h.renderCmd(cmd)),
+			))
+		}
 		if err == nil {
 			continue
 		}
@@ -170,19 +227,40 @@ func logDuration(ctx context.Context, cmds []red.Cmder, duration time.Duration)
 		if k > 0 {
 			buf.WriteByte('\n')
 		}
-		var build strings.Builder
-		for i, arg := range cmd.Args() {
-			if i > 0 {
-				build.WriteByte(' ')
-			}
-			build.WriteString(mapping.Repr(arg))
-		}
-		buf.WriteString(build.String())
+		buf.WriteString(renderArgs(cmd))
 	}
 	logx.WithContext(ctx).WithDuration(duration).Slowf("[REDIS] slowcall on executing: %s", buf.String())
 }
 
-func (h hook) startSpan(ctx context.Context, cmds ...red.Cmder) context.Context {
+// renderArgs renders a command and its arguments with mapping.Repr, the same
+// logic logDuration has always used for slowlog lines.
+func renderArgs(cmd red.Cmder) string {
+	var build strings.Builder
+	for i, arg := range cmd.Args() {
+		if i > 0 {
+			build.WriteByte(' ')
+		}
+		build.WriteString(mapping.Repr(arg))
+	}
+	return build.String()
+}
+
+// renderCmd renders cmd for the db.statement attribute, truncated to
+// maxStatementLength when it's positive.
+func (h *hook) renderCmd(cmd red.Cmder) string {
+	if !h.statementAttribute {
+		return ""
+	}
+
+	s := renderArgs(cmd)
+	if h.maxStatementLength > 0 && len(s) > h.maxStatementLength {
+		s = s[:h.maxStatementLength]
+	}
+
+	return s
+}
+
+func (h *hook) startSpan(ctx context.Context, cmds ...red.Cmder) context.Context {
 	tracer := trace.TracerFromContext(ctx)
 
 	ctx, span := tracer.Start(ctx,
@@ -194,12 +272,26 @@ func (h hook) startSpan(ctx context.Context, cmds ...red.Cmder) context.Context
 	for _, cmd := range cmds {
 		cmdStrs = append(cmdStrs, cmd.Name())
 	}
-	span.SetAttributes(redisCmdsAttributeKey.StringSlice(cmdStrs))
+
+	attrs := []attribute.KeyValue{
+		redisCmdsAttributeKey.StringSlice(cmdStrs),
+		dbSystemAttributeKey.String("redis"),
+		dbIndexAttributeKey.Int(h.dbIndex),
+	}
+	if host, port, err := net.SplitHostPort(h.addr); err == nil {
+		attrs = append(attrs, netPeerNameAttributeKey.String(host), netPeerPortAttributeKey.String(port))
+	}
+	if len(cmds) > 1 {
+		attrs = append(attrs, pipelineLengthAttributeKey.Int(len(cmds)))
+	} else if len(cmds) == 1 && h.statementAttribute {
+		attrs = append(attrs, dbStatementAttributeKey.String(h.renderCmd(cmds[0])))
+	}
+	span.SetAttributes(attrs...)
 
 	return ctx
 }
 
-func (h hook) endSpan(ctx context.Context, err error) {
+func (h *hook) endSpan(ctx context.Context, err error) {
 	span := oteltrace.SpanFromContext(ctx)
 	defer span.End()
 
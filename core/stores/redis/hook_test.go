@@ -0,0 +1,80 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	red "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+func TestNewHookDefaultsCaptureStatements(t *testing.T) {
+	h := newHook(RedisConf{}, parsedAddr{})
+	assert.True(t, h.statementAttribute)
+	assert.Equal(t, defaultMaxStatementLength, h.maxStatementLength)
+}
+
+func TestNewHookRespectsRedisConfStatementSettings(t *testing.T) {
+	h := newHook(RedisConf{DisableStatementAttribute: true, MaxStatementLength: 42}, parsedAddr{})
+	assert.False(t, h.statementAttribute)
+	assert.Equal(t, 42, h.maxStatementLength)
+}
+
+// recordingHook is a user hook standing in for the kind of concern WithHooks
+// is meant to layer on top of the built-in hook (log redaction, per-tenant
+// metrics, ...). It records what it observed in ctx when it ran.
+type recordingHook struct {
+	ran          bool
+	sawStartTime bool
+	sawSpan      bool
+}
+
+func (h *recordingHook) DialHook(next red.DialHook) red.DialHook {
+	return next
+}
+
+func (h *recordingHook) ProcessHook(next red.ProcessHook) red.ProcessHook {
+	return func(ctx context.Context, cmd red.Cmder) error {
+		h.ran = true
+		_, h.sawStartTime = ctx.Value(startTimeKey).(time.Duration)
+		h.sawSpan = oteltrace.SpanFromContext(ctx) != nil
+		return next(ctx, cmd)
+	}
+}
+
+func (h *recordingHook) ProcessPipelineHook(next red.ProcessPipelineHook) red.ProcessPipelineHook {
+	return next
+}
+
+func TestRedisAddHookRunsAfterBuiltinHookAndSharesContext(t *testing.T) {
+	s := miniredis.RunT(t)
+
+	r, err := newRedis(RedisConf{Host: s.Addr()})
+	assert.NoError(t, err)
+	defer r.Close()
+
+	userHook := new(recordingHook)
+	r.AddHook(userHook)
+
+	_ = r.client.Ping(context.Background()).Err()
+
+	assert.True(t, userHook.ran)
+	assert.True(t, userHook.sawStartTime)
+	assert.True(t, userHook.sawSpan)
+}
+
+func TestWithHooksRunsUserHook(t *testing.T) {
+	s := miniredis.RunT(t)
+
+	userHook := new(recordingHook)
+	r, err := newRedis(RedisConf{Host: s.Addr()}, WithHooks(userHook))
+	assert.NoError(t, err)
+	defer r.Close()
+
+	_ = r.client.Ping(context.Background()).Err()
+
+	assert.True(t, userHook.ran)
+}
@@ -0,0 +1,34 @@
+package redis
+
+// An Option customizes how a Redis is built by NewRedis/MustNewRedis.
+type Option func(r *Redis)
+
+// WithHooks appends hs to the Redis's hook chain, after the built-in
+// tracing/metrics/slowlog hook, so callers can layer their own concerns
+// (log redaction, per-tenant metrics, circuit breaking, cache-aside
+// instrumentation, ...) without losing the built-in instrumentation.
+func WithHooks(hs ...Hook) Option {
+	return func(r *Redis) {
+		for _, h := range hs {
+			r.AddHook(h)
+		}
+	}
+}
+
+// WithStatementAttribute toggles whether the built-in hook attaches a
+// rendered db.statement attribute (and per-command pipeline events) to
+// spans. Operators handling PII may want to disable this while keeping
+// command names and timing.
+func WithStatementAttribute(enabled bool) Option {
+	return func(r *Redis) {
+		r.hook.statementAttribute = enabled
+	}
+}
+
+// WithMaxStatementLength caps the length of the rendered db.statement
+// attribute the built-in hook attaches to spans. n <= 0 disables the cap.
+func WithMaxStatementLength(n int) Option {
+	return func(r *Redis) {
+		r.hook.maxStatementLength = n
+	}
+}
@@ -0,0 +1,32 @@
+// Package redistest provides an in-process miniredis-backed Redis for tests
+// that need a real go-redis client talking to something that actually
+// answers RESP commands, without requiring a live redis server.
+package redistest
+
+import (
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+)
+
+// CreateRedis returns a Redis backed by an in-process miniredis instance,
+// closed automatically when t ends.
+func CreateRedis(t *testing.T) *redis.Redis {
+	r, clean := CreateRedisWithClean(t)
+	t.Cleanup(clean)
+	return r
+}
+
+// CreateRedisWithClean returns a Redis backed by an in-process miniredis
+// instance and a clean func the caller must invoke to tear it down.
+func CreateRedisWithClean(t *testing.T) (r *redis.Redis, clean func()) {
+	mr := miniredis.RunT(t)
+
+	r, err := redis.NewRedis(redis.RedisConf{Host: mr.Addr(), Type: redis.NodeType})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return r, mr.Close
+}
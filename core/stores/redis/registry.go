@@ -0,0 +1,119 @@
+package redis
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// A registryEntry is a reference-counted Redis shared by every caller whose
+// RedisConf canonicalizes to the same key.
+type registryEntry struct {
+	redis *Redis
+	refs  int
+}
+
+var (
+	registryLock sync.Mutex
+	registryMap  = make(map[string]*registryEntry)
+)
+
+// Shared returns a process-wide Redis for rc, building the underlying
+// go-redis client (with the tracing/metrics hook installed) only once per
+// distinct addrs+db+username+tls combination, regardless of how many
+// go-zero components (cache, ratelimit, pubsub, dq, ...) ask for it.
+// Every call must be paired with a call to Redis.Close to release the
+// reference; the pool is only torn down once the last holder releases it.
+//
+// opts only take effect for the first caller that creates the shared
+// client; later callers that hit the cache get the already-built client
+// as-is, and any opts they pass are logged and ignored.
+func Shared(rc RedisConf, opts ...Option) (*Redis, error) {
+	key, err := canonicalKey(rc)
+	if err != nil {
+		return nil, err
+	}
+
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	if entry, ok := registryMap[key]; ok {
+		if len(opts) > 0 {
+			logx.Errorf("redis: Shared(%s) reused an existing client, ignoring %d option(s) "+
+				"passed by this caller; options only apply to the caller that creates the client",
+				key, len(opts))
+		}
+		entry.refs++
+		return entry.redis, nil
+	}
+
+	r, err := newRedis(rc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	r.sharedKey = key
+
+	registryMap[key] = &registryEntry{redis: r, refs: 1}
+	return r, nil
+}
+
+// MustShared is like Shared but exits on any error.
+func MustShared(rc RedisConf, opts ...Option) *Redis {
+	r, err := Shared(rc, opts...)
+	logx.Must(err)
+	return r
+}
+
+// releaseShared decrements the reference count for key, closing and
+// removing the underlying client once the last holder releases it.
+func releaseShared(key string) error {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	entry, ok := registryMap[key]
+	if !ok {
+		return nil
+	}
+
+	entry.refs--
+	if entry.refs > 0 {
+		return nil
+	}
+
+	delete(registryMap, key)
+	return entry.redis.client.Close()
+}
+
+// canonicalKey canonicalizes rc into a registry key from its addrs, db,
+// username, whether the resolved address implies TLS (e.g. a rediss://
+// URL), and a TLS fingerprint, ignoring fields (pool sizes, timeouts,
+// password) that don't change which underlying connection is wanted. It
+// uses resolveAddr rather than parseHost so that a cache hit never reads
+// rc.Tls's CA/cert/key files back off disk; those are only needed the one
+// time a new client is actually dialed. pa.tls is folded in so a rediss://
+// config never collides with the plaintext redis:// config for the same
+// host and shares its connection's security posture.
+func canonicalKey(rc RedisConf) (string, error) {
+	pa, err := resolveAddr(rc)
+	if err != nil {
+		return "", err
+	}
+
+	addrs := append([]string(nil), pa.addrs...)
+	sort.Strings(addrs)
+
+	return fmt.Sprintf("%s|%s|%d|%s|%s|%t|%s", pa.kind, strings.Join(addrs, ","),
+		pa.db, pa.username, pa.masterName, pa.tls != nil, tlsFingerprint(rc.Tls)), nil
+}
+
+func tlsFingerprint(tc TlsConf) string {
+	if !tc.Enabled {
+		return "notls"
+	}
+
+	return fmt.Sprintf("tls:%s:%s:%s:%s:%t", tc.CaFile, tc.CertFile, tc.KeyFile,
+		tc.ServerName, tc.InsecureSkipVerify)
+}
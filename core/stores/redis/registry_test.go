@@ -0,0 +1,57 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSharedReturnsSameClientForSameConf(t *testing.T) {
+	rc := RedisConf{Host: "localhost:6379", Type: NodeType}
+
+	r1, err := Shared(rc)
+	assert.NoError(t, err)
+	defer r1.Close()
+
+	r2, err := Shared(rc)
+	assert.NoError(t, err)
+	defer r2.Close()
+
+	assert.Same(t, r1, r2)
+}
+
+func TestSharedRefCountsCloseUntilLastHolder(t *testing.T) {
+	rc := RedisConf{Host: "localhost:6380", Type: NodeType}
+
+	r1, err := Shared(rc)
+	assert.NoError(t, err)
+
+	r2, err := Shared(rc)
+	assert.NoError(t, err)
+
+	key, err := canonicalKey(rc)
+	assert.NoError(t, err)
+
+	assert.NoError(t, r1.Close())
+	registryLock.Lock()
+	_, ok := registryMap[key]
+	registryLock.Unlock()
+	assert.True(t, ok, "pool should stay open while a holder remains")
+
+	assert.NoError(t, r2.Close())
+	registryLock.Lock()
+	_, ok = registryMap[key]
+	registryLock.Unlock()
+	assert.False(t, ok, "pool should be torn down once the last holder closes")
+}
+
+func TestCanonicalKeyDiffersForPlaintextAndTlsUrls(t *testing.T) {
+	plain, err := canonicalKey(RedisConf{Host: "redis://host:6379/0"})
+	assert.NoError(t, err)
+
+	secure, err := canonicalKey(RedisConf{Host: "rediss://host:6379/0"})
+	assert.NoError(t, err)
+
+	assert.NotEqual(t, plain, secure,
+		"a rediss:// config must never dedup onto a redis:// config for the same host")
+}
@@ -0,0 +1,345 @@
+package redis
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// defaultRingHealthCheckInterval is how often an unresponsive shard is
+// probed for recovery and a healthy shard is probed for failure.
+const defaultRingHealthCheckInterval = time.Second * 5
+
+// A RingShardConf is one shard of a Ring: a standalone RedisConf plus an
+// optional rendezvous-hashing weight (default 1). A shard with weight 2
+// receives roughly twice the keys of a weight-1 shard.
+type RingShardConf struct {
+	RedisConf
+	Weight int `json:",default=1"`
+}
+
+// A RingConf configures a Ring: N standalone Redis endpoints, keyed by a
+// shard name used to seed the rendezvous hash so adding/removing one shard
+// only remaps ~1/N of keys.
+type RingConf struct {
+	Shards map[string]RingShardConf
+}
+
+// A Ring fronts several standalone Redis endpoints and routes each key to a
+// shard with rendezvous (HRW) hashing, giving horizontal scale-out without
+// requiring Redis Cluster. It implements Get, Set, Del and the fan-out MGet
+// directly; for everything else, use Pick to get the *Redis that owns a key.
+type Ring struct {
+	healthCheckInterval time.Duration
+
+	lock   sync.RWMutex
+	shards map[string]*ringShard
+	closed bool
+
+	done chan struct{}
+}
+
+type ringShard struct {
+	name    string
+	seed    uint64
+	weight  int
+	redis   *Redis
+	healthy atomicBool
+}
+
+// NewRing builds a Ring from conf, installing the tracing/metrics hook on
+// every underlying shard client (via NewRedis) and starting a background
+// health check that removes an unresponsive shard from the hashing set and
+// re-adds it once it recovers.
+func NewRing(conf RingConf) (*Ring, error) {
+	if len(conf.Shards) == 0 {
+		return nil, errHostEmpty
+	}
+
+	shards := make(map[string]*ringShard, len(conf.Shards))
+	for name, sc := range conf.Shards {
+		weight := sc.Weight
+		if weight <= 0 {
+			weight = 1
+		}
+
+		r, err := NewRedis(sc.RedisConf)
+		if err != nil {
+			closeShards(shards)
+			return nil, err
+		}
+
+		shard := &ringShard{
+			name:   name,
+			seed:   hash64([]byte(name)),
+			weight: weight,
+			redis:  r,
+		}
+		shard.healthy.set(true)
+		shards[name] = shard
+	}
+
+	ring := &Ring{
+		healthCheckInterval: defaultRingHealthCheckInterval,
+		shards:              shards,
+		done:                make(chan struct{}),
+	}
+	go ring.healthCheckLoop()
+
+	return ring, nil
+}
+
+// closeShards tears down every already-built shard client. Used when
+// NewRing fails partway through, so a bad shard config doesn't leak the
+// connection pools of the shards built before it.
+func closeShards(shards map[string]*ringShard) {
+	for _, shard := range shards {
+		if err := shard.redis.Close(); err != nil {
+			logx.Errorf("redis ring: failed to close shard %q after NewRing error: %v", shard.name, err)
+		}
+	}
+}
+
+// Pick returns the shard owning key, skipping shards the health check has
+// currently marked unresponsive.
+func (r *Ring) Pick(key string) (*Redis, error) {
+	shard, err := r.pickShard(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return shard.redis, nil
+}
+
+func (r *Ring) pickShard(key string) (*ringShard, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	keyHash := hash64([]byte(key))
+
+	var best *ringShard
+	var bestScore = math.Inf(1)
+	for _, shard := range r.shards {
+		if !shard.healthy.get() {
+			continue
+		}
+
+		score := rendezvousScore(shard.seed, keyHash, shard.weight)
+		if score < bestScore {
+			bestScore = score
+			best = shard
+		}
+	}
+
+	if best == nil {
+		return nil, errNoHealthyShard
+	}
+
+	return best, nil
+}
+
+// Get routes a GET to the shard owning key.
+func (r *Ring) Get(ctx context.Context, key string) (string, error) {
+	shard, err := r.pickShard(key)
+	if err != nil {
+		return "", err
+	}
+
+	return shard.redis.client.Get(ctx, key).Result()
+}
+
+// Set routes a SET to the shard owning key.
+func (r *Ring) Set(ctx context.Context, key, value string, expire time.Duration) error {
+	shard, err := r.pickShard(key)
+	if err != nil {
+		return err
+	}
+
+	return shard.redis.client.Set(ctx, key, value, expire).Err()
+}
+
+// Del routes a DEL to the shard owning key.
+func (r *Ring) Del(ctx context.Context, key string) error {
+	shard, err := r.pickShard(key)
+	if err != nil {
+		return err
+	}
+
+	return shard.redis.client.Del(ctx, key).Err()
+}
+
+// MGet fans a multi-key GET out to each key's owning shard and reassembles
+// the results in the order the keys were given.
+func (r *Ring) MGet(ctx context.Context, keys ...string) ([]interface{}, error) {
+	results := make([]interface{}, len(keys))
+
+	type job struct {
+		index int
+		key   string
+	}
+	byShard := make(map[*ringShard][]job)
+	for i, key := range keys {
+		shard, err := r.pickShard(key)
+		if err != nil {
+			return nil, err
+		}
+		byShard[shard] = append(byShard[shard], job{index: i, key: key})
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+	for shard, jobs := range byShard {
+		shard, jobs := shard, jobs
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			shardKeys := make([]string, len(jobs))
+			for i, j := range jobs {
+				shardKeys[i] = j.key
+			}
+
+			vals, err := shard.redis.client.MGet(ctx, shardKeys...).Result()
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			for i, j := range jobs {
+				results[j.index] = vals[i]
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return results, nil
+}
+
+// Close tears down every shard's underlying client and stops the health
+// check loop. Calling Close more than once returns errRingClosed instead
+// of closing r.done a second time, which would panic.
+func (r *Ring) Close() error {
+	r.lock.Lock()
+	if r.closed {
+		r.lock.Unlock()
+		return errRingClosed
+	}
+	r.closed = true
+	r.lock.Unlock()
+
+	close(r.done)
+
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	var firstErr error
+	for _, shard := range r.shards {
+		if err := shard.redis.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+func (r *Ring) healthCheckLoop() {
+	ticker := time.NewTicker(r.healthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.checkShards()
+		case <-r.done:
+			return
+		}
+	}
+}
+
+func (r *Ring) checkShards() {
+	r.lock.RLock()
+	shards := make([]*ringShard, 0, len(r.shards))
+	for _, shard := range r.shards {
+		shards = append(shards, shard)
+	}
+	r.lock.RUnlock()
+
+	for _, shard := range shards {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		err := shard.redis.client.Ping(ctx).Err()
+		cancel()
+
+		wasHealthy := shard.healthy.get()
+		shard.healthy.set(err == nil)
+		if wasHealthy && err != nil {
+			logx.Errorf("redis ring: shard %q is unresponsive, removing from hashing set: %v", shard.name, err)
+		} else if !wasHealthy && err == nil {
+			logx.Infof("redis ring: shard %q recovered, re-adding to hashing set", shard.name)
+		}
+	}
+}
+
+// rendezvousScore computes the weighted HRW score of a shard (identified by
+// its seed) for a hashed key: score = -log(uniform01(mix64(seed XOR keyHash)))
+// / weight, the same formula as the Weighted Rendezvous Hashing paper and the
+// technique go-redis's own Ring uses via dgryski/go-rendezvous. The shard
+// with the lowest score owns the key.
+func rendezvousScore(seed, keyHash uint64, weight int) float64 {
+	u := uniform01(mix64(seed ^ keyHash))
+	if u <= 0 {
+		u = math.SmallestNonzeroFloat64
+	}
+
+	return -math.Log(u) / float64(weight)
+}
+
+// mix64 is a 64-bit finalizer (splitmix64-style) used to turn the XOR of a
+// shard seed and a key hash into a well-distributed value.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// uniform01 maps a 64-bit hash to a uniform float in [0, 1).
+func uniform01(h uint64) float64 {
+	return float64(h>>11) * (1.0 / (1 << 53))
+}
+
+func hash64(b []byte) uint64 {
+	h := fnv.New64a()
+	h.Write(b)
+	return h.Sum64()
+}
+
+type atomicBool struct {
+	mu    sync.RWMutex
+	value bool
+}
+
+func (b *atomicBool) set(v bool) {
+	b.mu.Lock()
+	b.value = v
+	b.mu.Unlock()
+}
+
+func (b *atomicBool) get() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.value
+}
@@ -0,0 +1,196 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestRing builds a Ring over n in-process miniredis shards, named
+// "shard-0".."shard-n-1", closed automatically when t ends.
+func newTestRing(t *testing.T, n int) *Ring {
+	shards := make(map[string]RingShardConf, n)
+	for i := 0; i < n; i++ {
+		s := miniredis.RunT(t)
+		shards[fmt.Sprintf("shard-%d", i)] = RingShardConf{
+			RedisConf: RedisConf{Host: s.Addr(), Type: NodeType},
+		}
+	}
+
+	ring, err := NewRing(RingConf{Shards: shards})
+	assert.NoError(t, err)
+	t.Cleanup(func() { ring.Close() })
+
+	return ring
+}
+
+func TestUniform01Bounds(t *testing.T) {
+	for i := uint64(0); i < 1000; i++ {
+		u := uniform01(mix64(i))
+		assert.GreaterOrEqual(t, u, 0.0)
+		assert.Less(t, u, 1.0)
+	}
+}
+
+func TestRendezvousScoreDeterministic(t *testing.T) {
+	seed := hash64([]byte("shard-a"))
+	keyHash := hash64([]byte("some-key"))
+
+	s1 := rendezvousScore(seed, keyHash, 1)
+	s2 := rendezvousScore(seed, keyHash, 1)
+	assert.Equal(t, s1, s2)
+}
+
+func TestRendezvousScoreWeightLowersScore(t *testing.T) {
+	seed := hash64([]byte("shard-a"))
+	keyHash := hash64([]byte("some-key"))
+
+	unweighted := rendezvousScore(seed, keyHash, 1)
+	weighted := rendezvousScore(seed, keyHash, 4)
+	// a lower score wins, so a higher weight must not raise the score.
+	assert.LessOrEqual(t, weighted, unweighted)
+}
+
+// TestRendezvousDistribution picks a winning shard for many keys across
+// equal-weight seeds and checks no shard is wildly over/under represented,
+// guarding against a broken mix that routes everything to one shard.
+func TestRendezvousDistribution(t *testing.T) {
+	shardNames := []string{"shard-0", "shard-1", "shard-2", "shard-3"}
+	seeds := make(map[string]uint64, len(shardNames))
+	for _, name := range shardNames {
+		seeds[name] = hash64([]byte(name))
+	}
+
+	counts := make(map[string]int, len(shardNames))
+	const numKeys = 20000
+	for i := 0; i < numKeys; i++ {
+		key := fmt.Sprintf("key-%d", i)
+		keyHash := hash64([]byte(key))
+
+		var best string
+		bestScore := -1.0
+		for _, name := range shardNames {
+			score := rendezvousScore(seeds[name], keyHash, 1)
+			if best == "" || score < bestScore {
+				best = name
+				bestScore = score
+			}
+		}
+		counts[best]++
+	}
+
+	expected := float64(numKeys) / float64(len(shardNames))
+	for _, name := range shardNames {
+		got := float64(counts[name])
+		assert.InDeltaf(t, expected, got, expected*0.15, "shard %s got %d keys, expected ~%v", name, counts[name], expected)
+	}
+}
+
+func TestNewRingRejectsEmptyShards(t *testing.T) {
+	_, err := NewRing(RingConf{})
+	assert.Error(t, err)
+}
+
+func TestRingCloseTwiceReturnsErrorInsteadOfPanicking(t *testing.T) {
+	ring, err := NewRing(RingConf{
+		Shards: map[string]RingShardConf{
+			"shard-0": {RedisConf: RedisConf{Host: "localhost:6381", Type: NodeType}},
+		},
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, ring.Close())
+	assert.Equal(t, errRingClosed, ring.Close())
+}
+
+func TestRingPickIsStableAndSpreadsAcrossShards(t *testing.T) {
+	ring := newTestRing(t, 3)
+
+	seen := make(map[string]int)
+	for i := 0; i < 300; i++ {
+		key := fmt.Sprintf("key-%d", i)
+
+		first, err := ring.Pick(key)
+		assert.NoError(t, err)
+
+		again, err := ring.Pick(key)
+		assert.NoError(t, err)
+		assert.Same(t, first, again, "the same key must always route to the same shard")
+
+		seen[first.Addrs[0]]++
+	}
+
+	assert.Greater(t, len(seen), 1, "300 keys across 3 shards should not all land on one shard")
+}
+
+func TestRingGetSetDel(t *testing.T) {
+	ring := newTestRing(t, 3)
+	ctx := context.Background()
+
+	assert.NoError(t, ring.Set(ctx, "some-key", "some-value", 0))
+
+	val, err := ring.Get(ctx, "some-key")
+	assert.NoError(t, err)
+	assert.Equal(t, "some-value", val)
+
+	assert.NoError(t, ring.Del(ctx, "some-key"))
+
+	_, err = ring.Get(ctx, "some-key")
+	assert.Error(t, err)
+}
+
+func TestRingMGetReassemblesInOriginalKeyOrder(t *testing.T) {
+	ring := newTestRing(t, 3)
+	ctx := context.Background()
+
+	keys := make([]string, 50)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("key-%d", i)
+		assert.NoError(t, ring.Set(ctx, keys[i], fmt.Sprintf("value-%d", i), 0))
+	}
+
+	vals, err := ring.MGet(ctx, keys...)
+	assert.NoError(t, err)
+	assert.Len(t, vals, len(keys))
+	for i, val := range vals {
+		assert.Equal(t, fmt.Sprintf("value-%d", i), val)
+	}
+}
+
+func TestCheckShardsExcludesUnresponsiveShardAndRecovers(t *testing.T) {
+	healthy := miniredis.RunT(t)
+	flaky := miniredis.RunT(t)
+
+	ring, err := NewRing(RingConf{
+		Shards: map[string]RingShardConf{
+			"healthy": {RedisConf: RedisConf{Host: healthy.Addr(), Type: NodeType}},
+			"flaky":   {RedisConf: RedisConf{Host: flaky.Addr(), Type: NodeType}},
+		},
+	})
+	assert.NoError(t, err)
+	defer ring.Close()
+
+	flaky.SetError("forced failure")
+	ring.checkShards()
+
+	for i := 0; i < 50; i++ {
+		shard, err := ring.pickShard(fmt.Sprintf("key-%d", i))
+		assert.NoError(t, err)
+		assert.Equal(t, healthy.Addr(), shard.redis.Addrs[0],
+			"an unresponsive shard must never be picked")
+	}
+
+	flaky.SetError("")
+	ring.checkShards()
+
+	seen := make(map[string]bool)
+	for i := 0; i < 50; i++ {
+		shard, err := ring.pickShard(fmt.Sprintf("key-%d", i))
+		assert.NoError(t, err)
+		seen[shard.redis.Addrs[0]] = true
+	}
+	assert.True(t, seen[flaky.Addr()], "the recovered shard should be back in the hashing set")
+}
@@ -0,0 +1,44 @@
+package redis
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// buildTLSConfig turns a TlsConf into a *tls.Config ready to hand to
+// red.Options/ClusterOptions/FailoverOptions, returning nil when TLS is
+// disabled so callers can assign it unconditionally.
+func buildTLSConfig(tc TlsConf) (*tls.Config, error) {
+	if !tc.Enabled {
+		return nil, nil
+	}
+
+	cfg := &tls.Config{
+		InsecureSkipVerify: tc.InsecureSkipVerify,
+		ServerName:         tc.ServerName,
+	}
+
+	if len(tc.CaFile) > 0 {
+		pool := x509.NewCertPool()
+		ca, err := os.ReadFile(tc.CaFile)
+		if err != nil {
+			return nil, fmt.Errorf("redis: read ca file %q: %w", tc.CaFile, err)
+		}
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("redis: no valid certificates found in %q", tc.CaFile)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if len(tc.CertFile) > 0 || len(tc.KeyFile) > 0 {
+		cert, err := tls.LoadX509KeyPair(tc.CertFile, tc.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("redis: load client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}